@@ -0,0 +1,215 @@
+package pen
+
+import (
+	"bufio"
+	"errors"
+	"os"
+	"runtime"
+	"strconv"
+	"sync"
+	"time"
+)
+
+/*
+Options controls the behavior of NewWithOptions.
+*/
+type Options struct {
+	// Workers is the size of the goroutine pool used to parse Node
+	// entries concurrently. If zero or negative, runtime.NumCPU()
+	// is used.
+	Workers int
+}
+
+/*
+workers returns the effective worker count for the receiver instance
+of Options, falling back to runtime.NumCPU() when unset.
+*/
+func (o Options) workers() int {
+	if o.Workers > 0 {
+		return o.Workers
+	}
+	return runtime.NumCPU()
+}
+
+/*
+nodeBlock holds the raw lines of a single Node entry: its leading
+decimal line, plus every non-blank line that follows it up to (but
+excluding) the next decimal-only line.
+*/
+type nodeBlock struct {
+	index int
+	lines []string
+}
+
+/*
+NewWithOptions parses the file specified via input argument, just as
+New does, but farms the per-Node parsing work out across a pool of
+opts.Workers goroutines rather than parsing strictly sequentially.
+
+The main goroutine makes a single pass over the file to both extract
+header fields and split the remainder into per-node blocks -- a block
+begins at a numeric-only line and ends just before the next one. Those
+blocks are then dispatched to the worker pool over a channel, and
+results are written back into a pre-sized slice keyed by each block's
+original position, so the resulting Nodes order is identical
+regardless of how the workers happen to interleave.
+*/
+func NewWithOptions(file string, opts Options) (ents *Enterprises, err error) {
+
+	var startParse int64 = time.Now().UnixNano()
+
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	ents = new(Enterprises)
+	scan := bufio.NewScanner(f)
+
+	var blocks []nodeBlock
+	var cur *nodeBlock
+
+	ct := 0
+	for scan.Scan() {
+		ct++
+		L := Line(scan.Text())
+
+		// A blank line is, vacuously, "numbers only" too (there are
+		// no non-digit characters to fail the check against), so it
+		// must be screened out before IsNumbersOnly is consulted --
+		// same ordering New/ParseStream use.
+		if L.IsZero() {
+			continue
+		}
+
+		if L.IsNumbersOnly() {
+			if cur != nil {
+				blocks = append(blocks, *cur)
+			}
+			cur = &nodeBlock{index: len(blocks), lines: []string{L.String()}}
+			continue
+		}
+
+		if cur != nil {
+			// Still within the current Node's block, collecting its
+			// Organization/Contact/Email lines.
+			cur.lines = append(cur.lines, L.String())
+			continue
+		}
+
+		// Lines 0 - 10 are for header info
+		if ct <= 10 {
+			if _, err := ents.setHeader(L, ct); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := scan.Err(); err != nil {
+		return nil, err
+	}
+	if cur != nil {
+		blocks = append(blocks, *cur)
+	}
+
+	nodes, err := parseBlocksConcurrently(blocks, opts.workers())
+	if err != nil {
+		return nil, err
+	}
+
+	// Dedup against a local map rather than routing each node through
+	// append (whose oidExists falls back to a linear scan, since
+	// decimalIndex isn't built until buildIndices below runs) -- that
+	// would make this assembly step O(n^2) and swamp the concurrent
+	// parsing work above.
+	seen := make(map[int]bool, len(nodes))
+	ents.Nodes = make([]Node, 0, len(nodes))
+	for i := range nodes {
+		if seen[nodes[i].Decimal] {
+			continue // duplicates silently ignored ...
+		}
+		seen[nodes[i].Decimal] = true
+		ents.Nodes = append(ents.Nodes, nodes[i])
+	}
+
+	ents.buildIndices()
+
+	doneParsed := time.Now().UnixNano()
+	ents.ParseTime = time.Duration(doneParsed - startParse)
+
+	return
+}
+
+/*
+parseBlocksConcurrently dispatches blocks across a pool of workers
+goroutines, each running parseNodeBlock, and returns the resulting
+Nodes in the same order as blocks -- i.e. deterministic regardless of
+worker count or scheduling.
+*/
+func parseBlocksConcurrently(blocks []nodeBlock, workers int) ([]Node, error) {
+	if len(blocks) == 0 {
+		return nil, nil
+	}
+	if workers > len(blocks) {
+		workers = len(blocks)
+	}
+	if workers < 1 {
+		workers = 1
+	}
+
+	results := make([]Node, len(blocks))
+	errs := make([]error, len(blocks))
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i], errs[i] = parseNodeBlock(blocks[i].lines)
+			}
+		}()
+	}
+
+	for _, b := range blocks {
+		jobs <- b.index
+	}
+	close(jobs)
+	wg.Wait()
+
+	for _, e := range errs {
+		if e != nil {
+			return nil, e
+		}
+	}
+
+	return results, nil
+}
+
+/*
+parseNodeBlock parses a single pre-split nodeBlock.lines -- the
+decimal line followed by up to three Organization/Contact/Email
+lines -- into a Node, routing the field assignment through the same
+fillNodeFields helper that the sequential, scanner-driven parseNode
+uses.
+*/
+func parseNodeBlock(lines []string) (n Node, err error) {
+	if len(lines) == 0 {
+		return Node{}, errors.New("pen: empty node block")
+	}
+
+	n.Decimal, err = strconv.Atoi(lines[0])
+	if err != nil {
+		return Node{}, err
+	}
+
+	rest := lines[1:]
+	if len(rest) > 3 {
+		rest = rest[:3]
+	}
+	fillNodeFields(&n, rest)
+
+	return
+}
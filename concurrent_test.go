@@ -0,0 +1,93 @@
+package pen
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+/*
+writePENFixture writes a synthetic n-Node PEN file to a temp directory
+and returns its path, for exercising New/NewWithOptions without a real
+IANA download.
+*/
+func writePENFixture(tb testing.TB, n int) string {
+	tb.Helper()
+
+	var b strings.Builder
+	b.WriteString("\nPRIVATE ENTERPRISE NUMBERS\n\n")
+	b.WriteString("(last updated 2024-01-02)\n\n")
+	b.WriteString("SMI Network Management Private Enterprise Codes:\n\n")
+	b.WriteString("PREFIX: 1.3.6.1.4.1 (1)\n\n")
+	b.WriteString("URL: http://www.iana.org/assignments/enterprise-numbers\n\n")
+
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "%d\n", i)
+		fmt.Fprintf(&b, "  Example Org %d\n", i)
+		fmt.Fprintf(&b, "    Contact %d\n", i)
+		fmt.Fprintf(&b, "      user%d&example.com\n\n", i)
+	}
+
+	dir := tb.TempDir()
+	path := filepath.Join(dir, `pen.txt`)
+	if err := os.WriteFile(path, []byte(b.String()), 0o644); err != nil {
+		tb.Fatalf("writing fixture: %v", err)
+	}
+	return path
+}
+
+/*
+TestNewWithOptionsDeterministic verifies that NewWithOptions produces
+identical Nodes regardless of worker count, as the worker-pool
+dispatch in parseBlocksConcurrently promises.
+*/
+func TestNewWithOptionsDeterministic(t *testing.T) {
+	path := writePENFixture(t, 500)
+
+	serial, err := NewWithOptions(path, Options{Workers: 1})
+	if err != nil {
+		t.Fatalf("NewWithOptions(Workers: 1): %v", err)
+	}
+
+	parallel, err := NewWithOptions(path, Options{Workers: 8})
+	if err != nil {
+		t.Fatalf("NewWithOptions(Workers: 8): %v", err)
+	}
+
+	if !reflect.DeepEqual(serial.Nodes, parallel.Nodes) {
+		t.Fatalf("Nodes differ between worker counts")
+	}
+}
+
+/*
+BenchmarkNewSerial measures the sequential, scanner-driven New/
+ParseStream path against a synthetic full-size PEN file.
+*/
+func BenchmarkNewSerial(b *testing.B) {
+	path := writePENFixture(b, 60000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := New(path); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+/*
+BenchmarkNewWithOptionsParallel measures the worker-pool path against
+the same synthetic PEN file used by BenchmarkNewSerial.
+*/
+func BenchmarkNewWithOptionsParallel(b *testing.B) {
+	path := writePENFixture(b, 60000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, err := NewWithOptions(path, Options{}); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
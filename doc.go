@@ -8,9 +8,12 @@ Jesse Coretta (subcon42)
 
 Advisory
 
-You must download the PEN file yourself using your preferred HTTP
-client.  The New() method takes the local filesystem path of that
-downloaded file (e.g: /tmp/pen.txt).
+You may download the PEN file yourself using your preferred HTTP
+client and hand it to New() via its local filesystem path (e.g:
+/tmp/pen.txt), or let this package do the downloading for you via
+Fetch() or NewFromURL(). Fetch() additionally supports on-disk caching
+and conditional GET so repeated, scheduled pulls don't re-download an
+unchanged file; NewFromURL() does no caching.
 
 The URL for the PEN file is below (don't click this URL unless you
 really mean it, as the file is literally hundreds of thousands of
@@ -18,8 +21,8 @@ lines long):
 
 http://www.iana.org/assignments/enterprise-numbers/enterprise-numbers
 
-DO NOT MANUALLY EDIT THIS DOWNLOADED FILE, OR YOU WILL SUFFER MANY
-BIZARRE PROBLEMS.
+DO NOT MANUALLY EDIT A DOWNLOADED COPY OF THIS FILE, OR YOU WILL
+SUFFER MANY BIZARRE PROBLEMS.
 
 Keep in mind, this is a very rough and unofficial draft; subject
 to change without notice!
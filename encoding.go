@@ -0,0 +1,232 @@
+package pen
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+/*
+nodeExport mirrors Node for the purposes of structured export, adding
+the derived OID/IRI/ASN values alongside the raw parsed fields so that
+downstream tooling need not recompute them.
+*/
+type nodeExport struct {
+	Decimal      int      `json:"decimal" xml:"decimal"`
+	OID          string   `json:"oid" xml:"oid"`
+	IRI          string   `json:"iri" xml:"iri"`
+	ASN          string   `json:"asn" xml:"asn"`
+	Organization string   `json:"organization" xml:"organization"`
+	Contact      string   `json:"contact" xml:"contact"`
+	Email        []string `json:"email" xml:"email>address"`
+}
+
+func (n Node) export() nodeExport {
+	return nodeExport{
+		Decimal:      n.Decimal,
+		OID:          n.OID(),
+		IRI:          n.IRI(),
+		ASN:          n.ASN(),
+		Organization: n.Organization,
+		Contact:      n.Contact,
+		Email:        n.Email,
+	}
+}
+
+/*
+MarshalJSON allows Node to satisfy json.Marshaler, emitting its raw
+fields plus the derived OID/IRI/ASN values.
+*/
+func (n Node) MarshalJSON() ([]byte, error) {
+	return json.Marshal(n.export())
+}
+
+/*
+enterprisesExport mirrors Enterprises for the purposes of structured
+export, flattening Header() into the shape consumed by
+MarshalJSON/WriteXML. WriteYAML emits the same keys but writes them
+directly rather than through this type.
+*/
+type enterprisesExport struct {
+	XMLName xml.Name     `json:"-" xml:"enterprises"`
+	Header  headerExport `json:"header" xml:"header"`
+	Nodes   []nodeExport `json:"nodes" xml:"nodes>node"`
+}
+
+type headerExport struct {
+	Title          string `json:"title" xml:"title"`
+	Source         string `json:"source" xml:"source"`
+	Section        string `json:"section" xml:"section"`
+	Entries        int    `json:"entries" xml:"entries"`
+	Duration       string `json:"duration" xml:"duration"`
+	LastUpdated    string `json:"lastUpdated" xml:"lastUpdated"`
+	RemoteModified string `json:"remoteModified,omitempty" xml:"remoteModified,omitempty"`
+	OID            string `json:"oid" xml:"oid"`
+	IRI            string `json:"iri" xml:"iri"`
+	ASN            string `json:"asn" xml:"asn"`
+}
+
+func (e Enterprises) export() enterprisesExport {
+	nodes := make([]nodeExport, len(e.Nodes))
+	for i := range e.Nodes {
+		nodes[i] = e.Nodes[i].export()
+	}
+
+	return enterprisesExport{
+		Header: headerExport{
+			Title:          e.Title,
+			Source:         e.URI(),
+			Section:        e.Section,
+			Entries:        e.Count(),
+			Duration:       e.parseTimeString(),
+			LastUpdated:    e.LastUpdated.Format(dateFormat),
+			RemoteModified: e.remoteModifiedString(),
+			OID:            enterpriseOID,
+			IRI:            enterpriseIRI,
+			ASN:            strings.Replace(enterpriseASN1, ` <--X-->`, ``, 1),
+		},
+		Nodes: nodes,
+	}
+}
+
+/*
+MarshalJSON allows Enterprises to satisfy json.Marshaler, emitting the
+full Header() block alongside every parsed Node.
+*/
+func (e Enterprises) MarshalJSON() ([]byte, error) {
+	return json.Marshal(e.export())
+}
+
+/*
+WriteJSON writes the receiver instance, in its entirety, to w as
+indented JSON. See MarshalJSON for the emitted schema.
+*/
+func (e Enterprises) WriteJSON(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent(``, `  `)
+	return enc.Encode(e)
+}
+
+/*
+WriteXML writes the receiver instance, in its entirety, to w as
+indented XML, using the same field set as MarshalJSON.
+*/
+func (e Enterprises) WriteXML(w io.Writer) error {
+	enc := xml.NewEncoder(w)
+	enc.Indent(``, `  `)
+	return enc.Encode(e.export())
+}
+
+/*
+WriteCSV writes one row per Node to w, preceded by a stable header
+row of: Decimal, OID, IRI, ASN, Organization, Contact, Email. Multiple
+email addresses for a single Node are joined with a semicolon.
+*/
+func (e Enterprises) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+
+	if err := cw.Write([]string{`Decimal`, `OID`, `IRI`, `ASN`, `Organization`, `Contact`, `Email`}); err != nil {
+		return err
+	}
+
+	for i := range e.Nodes {
+		n := e.Nodes[i]
+		row := []string{
+			strconv.Itoa(n.Decimal),
+			n.OID(),
+			n.IRI(),
+			n.ASN(),
+			n.Organization,
+			n.Contact,
+			strings.Join(n.Email, `;`),
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+/*
+WriteYAML writes the receiver instance, in its entirety, to w as YAML.
+Keys are emitted in a fixed order (rather than ranging over Header(),
+whose map ordering is unspecified) so that output is stable across
+calls.
+*/
+func (e Enterprises) WriteYAML(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw, `header:`)
+	fmt.Fprintf(bw, "  title: %q\n", e.Title)
+	fmt.Fprintf(bw, "  source: %q\n", e.URI())
+	fmt.Fprintf(bw, "  section: %q\n", e.Section)
+	fmt.Fprintf(bw, "  entries: %d\n", e.Count())
+	fmt.Fprintf(bw, "  duration: %q\n", e.parseTimeString())
+	fmt.Fprintf(bw, "  lastUpdated: %q\n", e.LastUpdated.Format(dateFormat))
+	if rm := e.remoteModifiedString(); rm != `` {
+		fmt.Fprintf(bw, "  remoteModified: %q\n", rm)
+	}
+	fmt.Fprintf(bw, "  oid: %q\n", enterpriseOID)
+	fmt.Fprintf(bw, "  iri: %q\n", enterpriseIRI)
+	fmt.Fprintf(bw, "  asn: %q\n", strings.Replace(enterpriseASN1, ` <--X-->`, ``, 1))
+
+	fmt.Fprintln(bw, `nodes:`)
+	for i := range e.Nodes {
+		n := e.Nodes[i]
+		fmt.Fprintf(bw, "  - decimal: %d\n", n.Decimal)
+		fmt.Fprintf(bw, "    oid: %q\n", n.OID())
+		fmt.Fprintf(bw, "    iri: %q\n", n.IRI())
+		fmt.Fprintf(bw, "    asn: %q\n", n.ASN())
+		fmt.Fprintf(bw, "    organization: %q\n", n.Organization)
+		fmt.Fprintf(bw, "    contact: %q\n", n.Contact)
+		fmt.Fprintln(bw, `    email:`)
+		for _, em := range n.Email {
+			fmt.Fprintf(bw, "      - %q\n", em)
+		}
+	}
+
+	return bw.Flush()
+}
+
+/*
+WriteText re-emits the receiver instance in the canonical IANA PEN
+file format: a ten-line header (matching the layout setHeader expects
+on the way back in) followed by a four-line block per Node. The
+result is round-trippable: passing the output of WriteText to New
+yields an *Enterprises with identical Nodes to the receiver.
+*/
+func (e Enterprises) WriteText(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+
+	fmt.Fprintln(bw)
+	fmt.Fprintln(bw, e.Title)
+	fmt.Fprintln(bw)
+	fmt.Fprintf(bw, "(last updated %s)\n", e.LastUpdated.Format(penDateFormat))
+	fmt.Fprintln(bw)
+	fmt.Fprintf(bw, "%s:\n", e.Section)
+	fmt.Fprintln(bw)
+
+	iriPath := strings.ReplaceAll(strings.TrimPrefix(enterpriseIRI, `/`), `/`, `.`)
+	fmt.Fprintf(bw, "PREFIX: %s (%s)\n", iriPath, enterpriseOID)
+	fmt.Fprintln(bw)
+	fmt.Fprintf(bw, "URL: %s\n", e.URI())
+	fmt.Fprintln(bw)
+
+	for i := range e.Nodes {
+		n := e.Nodes[i]
+		fmt.Fprintln(bw, n.Decimal)
+		fmt.Fprintf(bw, "  %s\n", n.Organization)
+		fmt.Fprintf(bw, "    %s\n", n.Contact)
+		fmt.Fprintf(bw, "      %s\n", strings.Join(n.Email, `,`))
+		fmt.Fprintln(bw)
+	}
+
+	return bw.Flush()
+}
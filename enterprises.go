@@ -1,13 +1,11 @@
 package pen
 
 import (
-	"bufio"
-	"bytes"
 	"encoding/asn1"
 	"errors"
 	"fmt"
-	"io/ioutil"
 	"net/url"
+	"os"
 	"strconv"
 	"strings"
 	"time"
@@ -51,8 +49,28 @@ type Enterprises struct {
 	SourceURI   *url.URL
 	ParseTime   time.Duration
 	LastUpdated time.Time
+
+	// RemoteModified holds the HTTP Last-Modified value observed
+	// during the most recent Fetch/NewFromURL call, if any. Unlike
+	// LastUpdated (which reflects the date IANA itself stamped
+	// inside the file), this is the server's own last-modification
+	// time for the copy that was fetched (or reused from the cache).
+	RemoteModified time.Time
+
 	Title,
 	Section		string
+
+	// decimalIndex, emailIndex, contactIndex and orgIndex/orgNames
+	// are built once by buildIndices, immediately after parsing, so
+	// that FindBy* lookups need not walk the full Nodes slice. They
+	// are left as their zero values (nil) on an Enterprises that was
+	// constructed by hand rather than via New/Fetch/ParseStream, in
+	// which case FindBy* falls back to a linear scan.
+	decimalIndex map[int]int
+	emailIndex   map[string][]int
+	contactIndex map[string][]int
+	orgIndex     map[string][]int
+	orgNames     []string
 }
 
 /*
@@ -89,38 +107,43 @@ index number of the Node in question as reported by the
 receiver instance of Enterprises.
 */
 func (e Enterprises) oidExists(dec interface{}) (bool, int) {
-	for el := range e.Nodes {
-		switch tv := dec.(type) {
-		case asn1.ObjectIdentifier:
-			return e.oidExists([]int(tv))
-		case string:
-			if x, err := strconv.Atoi(tv); err == nil {
-				return e.oidExists(x)
-			}
-			if x := strings.Split(tv, `.`); len(x) >= 1 {
-				return e.oidExists(x[len(x)-1])
-			}
-		case int:
-			if tv < 0 {
-				return false, -1
-			}
+	switch tv := dec.(type) {
+	case asn1.ObjectIdentifier:
+		return e.oidExists([]int(tv))
+	case string:
+		if x, err := strconv.Atoi(tv); err == nil {
+			return e.oidExists(x)
+		}
+		if x := strings.Split(tv, `.`); len(x) >= 1 {
+			return e.oidExists(x[len(x)-1])
+		}
+	case int:
+		if tv < 0 {
+			return false, -1
+		}
 
+		if e.decimalIndex != nil {
+			idx, ok := e.decimalIndex[tv]
+			return ok, idx
+		}
+
+		for el := range e.Nodes {
 			if e.Nodes[el].Decimal == tv {
 				return true, el
 			}
-		case []int:
-			if len(tv) <= 1 {
+		}
+	case []int:
+		if len(tv) <= 1 {
 
-				return false, -1
-			}
+			return false, -1
+		}
 
-			// Don't bother running another loop if the
-			// OID prefix is bogus to begin with ...
-			if asn1.ObjectIdentifier(tv[:len(tv)-1]).String() != enterpriseOID {
-				return false, -1
-			}
-			return e.oidExists(tv[len(tv)-1])
+		// Don't bother running another loop if the
+		// OID prefix is bogus to begin with ...
+		if asn1.ObjectIdentifier(tv[:len(tv)-1]).String() != enterpriseOID {
+			return false, -1
 		}
+		return e.oidExists(tv[len(tv)-1])
 	}
 	return false, -1
 }
@@ -144,6 +167,21 @@ observed during a looped search, and the provided IRI value (iri).
 func (e Enterprises) FindByIRI(iri string) (Node, bool) {
 	iri = strings.ToLower(iri)
 
+	// The only variable component of an IRI is the trailing decimal
+	// node number, so a match can be routed through decimalIndex by
+	// peeling it off the end, rather than rendering and comparing
+	// every Node's IRI in turn.
+	prefix := strings.ToLower(enterpriseIRI) + `/`
+	if strings.HasPrefix(iri, prefix) {
+		if dec, err := strconv.Atoi(iri[len(prefix):]); err == nil {
+			exists, idx := e.oidExists(dec)
+			if exists {
+				return e.Nodes[idx], true
+			}
+			return emptyNode, false
+		}
+	}
+
 	for i := 0; i < e.Count(); i++ {
 		n := e.Nodes[i]
 		target := strings.ToLower(n.IRI())
@@ -165,6 +203,14 @@ If found an instance of Node is returned along with an affirmative
 boolean value; else an empty node and a negative boolean value.
 */
 func (e Enterprises) FindByEmail(email string) (Node, bool) {
+	if e.emailIndex != nil {
+		key := emailKey(email)
+		if idxs, ok := e.emailIndex[key]; ok && len(idxs) > 0 {
+			return e.Nodes[idxs[0]], true
+		}
+		return emptyNode, false
+	}
+
 	for i := 0; i < e.Count(); i++ {
 		for em := 0; em < len(e.Nodes[i].Email); em++ {
 			email = strings.ReplaceAll(email, `&`, `@`)
@@ -184,6 +230,14 @@ each Contact name found within the Enterprises receiver instance
 and the provided name input argument.
 */
 func (e Enterprises) FindByContact(name string) (Node, bool) {
+	if e.contactIndex != nil {
+		key := contactKey(name)
+		if idxs, ok := e.contactIndex[key]; ok && len(idxs) > 0 {
+			return e.Nodes[idxs[0]], true
+		}
+		return emptyNode, false
+	}
+
 	for i := 0; i < e.Count(); i++ {
 		name = strings.ReplaceAll(name, ` `, ``)
 		target := strings.ReplaceAll(e.Nodes[i].Contact, ` `, ``)
@@ -195,12 +249,12 @@ func (e Enterprises) FindByContact(name string) (Node, bool) {
 	return emptyNode, false
 }
 
-func (e *Enterprises) setLastUpdated(lu line) bool {
-	if lu.len() <= 1 {
+func (e *Enterprises) setLastUpdated(lu Line) bool {
+	if lu.Len() <= 1 {
 		return false
 	}
 
-	lus := strings.Split(lu.string()[1:lu.len()-1], ` `)
+	lus := strings.Split(lu.String()[1:lu.Len()-1], ` `)
 	if len(lus) == 0 {
 		return false
 	}
@@ -210,23 +264,23 @@ func (e *Enterprises) setLastUpdated(lu line) bool {
 	return err == nil
 }
 
-func (e *Enterprises) setSection(sec line) bool {
-	if sec.len() <= 1 {
+func (e *Enterprises) setSection(sec Line) bool {
+	if sec.Len() <= 1 {
 		return false
 	}
 
-	e.Section = sec.string()[0 : sec.len()-1]
+	e.Section = sec.String()[0 : sec.Len()-1]
 	return true
 }
 
-func (e *Enterprises) setPrefix(pfx line) bool {
-	if pfx.len() <= 7 {
+func (e *Enterprises) setPrefix(pfx Line) bool {
+	if pfx.Len() <= 7 {
 		return false
 	}
 
-	npfx := line(pfx[8:pfx.len()])
+	npfx := Line(pfx[8:pfx.Len()])
 
-	pfxs := strings.Split(npfx.string(), ` `)
+	pfxs := strings.Split(npfx.String(), ` `)
 	if len(pfxs) >= 2 {
 		if len(pfxs[0])|len(pfxs[1]) <= 2 {
 			return false
@@ -242,13 +296,13 @@ func (e *Enterprises) setPrefix(pfx line) bool {
 	return false
 }
 
-func (e *Enterprises) setURI(uri line) bool {
-	if uri.len() <= 1 {
+func (e *Enterprises) setURI(uri Line) bool {
+	if uri.Len() <= 1 {
 		return false
 	}
 
 	var err error
-	f := strings.Split(uri.string(), ` `)
+	f := strings.Split(uri.String(), ` `)
 	e.SourceURI, err = url.Parse(f[len(f)-1])
 	if err != nil {
 		return false
@@ -264,6 +318,29 @@ func (e *Enterprises) URI() string {
 	return ``
 }
 
+/*
+remoteModifiedString renders RemoteModified using dateFormat, or an
+empty string if the receiver was never populated via Fetch/NewFromURL.
+*/
+func (e Enterprises) remoteModifiedString() string {
+	if e.RemoteModified.IsZero() {
+		return ``
+	}
+	return e.RemoteModified.Format(dateFormat)
+}
+
+/*
+parseTimeString renders ParseTime in the same "%d ms. (~%d sec.)" form
+used by Header(), so that form need not be duplicated wherever the
+header is surfaced (Header, export, WriteYAML).
+*/
+func (e Enterprises) parseTimeString() string {
+	return fmt.Sprintf("%d ms. (~%d sec.)",
+		e.ParseTime/time.Millisecond,
+		e.ParseTime/time.Second,
+	)
+}
+
 /*
 Count returns the number of Node instances present within
 the receiver instance of Enterprises.
@@ -283,11 +360,9 @@ func (e Enterprises) Header() map[string]map[string]interface{} {
 			`Source`: e.URI(),
 			`Section`: e.Section,
 			`Entries`: e.Count(),
-			`Duration`: fmt.Sprintf("%d ms. (~%d sec.)",
-				e.ParseTime/time.Millisecond,
-				e.ParseTime/time.Second,
-			),
+			`Duration`: e.parseTimeString(),
 			`LastUpdated`: e.LastUpdated.Format(dateFormat),
+			`RemoteModified`: e.remoteModifiedString(),
 		},
 		`Prefix`: map[string]interface{}{
 			`OID`: enterpriseOID,
@@ -298,11 +373,11 @@ func (e Enterprises) Header() map[string]map[string]interface{} {
 }
 
 // todo - make this moar better
-func (e *Enterprises) setHeader(l line, ct int) (bool, error) {
+func (e *Enterprises) setHeader(l Line, ct int) (bool, error) {
 
 	switch ct - 1 {
 	case 1:
-		e.Title = l.string() // no special processing needed
+		e.Title = l.String() // no special processing needed
 	case 3:
 		if ok := e.setLastUpdated(l); !ok {
 			return false, errors.New("Unable to set LastUpdated header value")
@@ -333,43 +408,23 @@ instance of *Enterprises shall be returned alongside a nil error.
 
 Note that you must download the IANA Private Enterprise Numbers List
 yourself (this package will not do that part for you).
+
+New opens file and streams it through ParseStream; see ParseStream
+for the underlying parsing behavior.
 */
 func New(file string) (ents *Enterprises, err error) {
 
 	var startParse int64 = time.Now().UnixNano()
 
-	penBytes, err := ioutil.ReadFile(file)
+	f, err := os.Open(file)
 	if err != nil {
 		return nil, err
 	}
+	defer f.Close()
 
-	ents = new(Enterprises)
-	scan := bufio.NewScanner(bytes.NewReader(penBytes))
-
-	ct := 0
-	for scan.Scan() {
-		ct++
-		L := line(scan.Text())
-		if L.isZero() {
-			continue
-		}
-
-		// Lines 0 - 10 are for header info
-		if ct <= 10 {
-			if _, err := ents.setHeader(L, ct); err != nil {
-				return nil, err
-			}
-		}
-
-		// Any line that is wholly numerical indicates
-		// the start of a new entry ...
-		if L.isNumbersOnly() {
-			if n, err := parseNode(scan, L); err == nil {
-				_ = ents.append(n) // duplicates silently ignored ...
-			} else {
-				return nil, err
-			}
-		}
+	ents, err = ParseStream(f)
+	if err != nil {
+		return nil, err
 	}
 
 	doneParsed := time.Now().UnixNano()
@@ -0,0 +1,277 @@
+package pen
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+)
+
+/*
+ianaPENURL is the canonical download location for the IANA Private
+Enterprise Numbers List, used whenever FetchOptions.URL is unset.
+*/
+const ianaPENURL = `http://www.iana.org/assignments/enterprise-numbers/enterprise-numbers`
+
+/*
+FetchOptions governs the behavior of Fetch when retrieving the IANA
+PEN file over HTTP.
+*/
+type FetchOptions struct {
+	// URL is the address from which the PEN file shall be
+	// retrieved. If zero value, ianaPENURL is used.
+	URL string
+
+	// CacheDir, if non-zero, is the directory in which the
+	// downloaded PEN file -- and small metadata describing its
+	// ETag/Last-Modified values -- shall be stored. Subsequent
+	// calls that share the same CacheDir and URL will issue a
+	// conditional GET and, upon receiving 304 Not Modified, will
+	// reparse the cached copy rather than re-downloading it.
+	//
+	// If zero value, no on-disk caching is performed, and every
+	// call to Fetch results in an unconditional GET.
+	CacheDir string
+
+	// Client is the *http.Client used to perform the request. If
+	// nil, http.DefaultClient is used. Callers wanting proxy or
+	// timeout support should supply their own configured Client.
+	Client *http.Client
+}
+
+/*
+url returns the effective URL for the receiver instance of
+FetchOptions, falling back to ianaPENURL when unset.
+*/
+func (o FetchOptions) url() string {
+	if o.URL == `` {
+		return ianaPENURL
+	}
+	return o.URL
+}
+
+/*
+client returns the effective *http.Client for the receiver instance
+of FetchOptions, falling back to http.DefaultClient when unset.
+*/
+func (o FetchOptions) client() *http.Client {
+	if o.Client == nil {
+		return http.DefaultClient
+	}
+	return o.Client
+}
+
+/*
+cacheMeta holds the conditional-GET validators we persist alongside
+a cached copy of the PEN file.
+*/
+type cacheMeta struct {
+	ETag         string `json:"etag,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+}
+
+/*
+cachePaths returns the on-disk path of the cached PEN body and its
+associated cacheMeta sidecar file, both derived from opts.CacheDir
+and opts.url(). Both return values are zero-value strings when no
+CacheDir was configured.
+*/
+func (o FetchOptions) cachePaths() (data, meta string) {
+	if o.CacheDir == `` {
+		return ``, ``
+	}
+	key := fmt.Sprintf("%x", sum64(o.url()))
+	return filepath.Join(o.CacheDir, key+`.pen`), filepath.Join(o.CacheDir, key+`.meta.json`)
+}
+
+/*
+sum64 is a small, dependency-free hash used only to derive a stable
+cache filename from a URL; it is not used for anything security
+sensitive.
+*/
+func sum64(s string) uint64 {
+	var h uint64 = 14695981039346656037
+	for i := 0; i < len(s); i++ {
+		h ^= uint64(s[i])
+		h *= 1099511628211
+	}
+	return h
+}
+
+/*
+readCacheMeta loads a previously-written cacheMeta from path. A
+missing or malformed file yields a zero-value cacheMeta and no error,
+since the absence of cache metadata simply means an unconditional GET
+will be issued.
+*/
+func readCacheMeta(path string) (m cacheMeta) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return
+	}
+	_ = json.Unmarshal(b, &m)
+	return
+}
+
+/*
+writeAtomic writes data to path by first writing to a temp file in
+the same directory, then renaming it into place, so that a reader
+never observes a partially-written cache file.
+*/
+func writeAtomic(path string, data []byte) error {
+	dir := filepath.Dir(path)
+	tmp, err := ioutil.TempFile(dir, `.pen-*`)
+	if err != nil {
+		return err
+	}
+	tmpName := tmp.Name()
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		os.Remove(tmpName)
+		return err
+	}
+	if err = tmp.Close(); err != nil {
+		os.Remove(tmpName)
+		return err
+	}
+
+	return os.Rename(tmpName, path)
+}
+
+/*
+Fetch retrieves the IANA PEN file described by opts over HTTP and
+returns a parsed *Enterprises instance.
+
+If opts.CacheDir is set and a cached copy already exists on disk,
+Fetch issues a conditional GET using If-None-Match / If-Modified-Since
+based on the ETag/Last-Modified values recorded the last time the
+cache was populated. A 304 Not Modified response short-circuits the
+request: rather than re-downloading, the cached copy on disk is
+reparsed directly.
+
+The returned *Enterprises has its RemoteModified field set from the
+response's Last-Modified header (if present), distinguishing the
+server's modification timestamp from LastUpdated, which reflects the date IANA
+stamped inside the file itself.
+
+The provided context governs cancellation/timeout of the underlying
+HTTP request.
+*/
+func Fetch(ctx context.Context, opts FetchOptions) (*Enterprises, error) {
+	dataPath, metaPath := opts.cachePaths()
+	usingCache := dataPath != ``
+
+	var meta cacheMeta
+	haveCached := false
+	if usingCache {
+		if _, err := os.Stat(dataPath); err == nil {
+			meta = readCacheMeta(metaPath)
+			haveCached = true
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, opts.url(), nil)
+	if err != nil {
+		return nil, err
+	}
+	if haveCached {
+		if meta.ETag != `` {
+			req.Header.Set(`If-None-Match`, meta.ETag)
+		}
+		if meta.LastModified != `` {
+			req.Header.Set(`If-Modified-Since`, meta.LastModified)
+		}
+	}
+
+	resp, err := opts.client().Do(req)
+	if err != nil {
+		if haveCached {
+			return newFromCache(dataPath, meta)
+		}
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusNotModified:
+		if !haveCached {
+			return nil, errors.New("pen: received 304 Not Modified but no cached copy exists on disk")
+		}
+		return newFromCache(dataPath, meta)
+
+	case http.StatusOK:
+		body, err := ioutil.ReadAll(resp.Body)
+		if err != nil {
+			return nil, err
+		}
+
+		meta = cacheMeta{
+			ETag:         resp.Header.Get(`ETag`),
+			LastModified: resp.Header.Get(`Last-Modified`),
+		}
+
+		if usingCache {
+			if err := writeAtomic(dataPath, body); err != nil {
+				return nil, err
+			}
+			if mb, err := json.Marshal(meta); err == nil {
+				_ = writeAtomic(metaPath, mb)
+			}
+			return newFromCache(dataPath, meta)
+		}
+
+		tmp, err := ioutil.TempFile(``, `pen-*.txt`)
+		if err != nil {
+			return nil, err
+		}
+		defer os.Remove(tmp.Name())
+
+		if _, err = tmp.Write(body); err != nil {
+			tmp.Close()
+			return nil, err
+		}
+		if err = tmp.Close(); err != nil {
+			return nil, err
+		}
+
+		return newFromCache(tmp.Name(), meta)
+
+	default:
+		return nil, fmt.Errorf("pen: unexpected HTTP status fetching %s: %s", opts.url(), resp.Status)
+	}
+}
+
+/*
+newFromCache parses the PEN file located at path via New, then stamps
+the resulting *Enterprises' RemoteModified field from meta.
+*/
+func newFromCache(path string, meta cacheMeta) (*Enterprises, error) {
+	ents, err := New(path)
+	if err != nil {
+		return nil, err
+	}
+
+	if meta.LastModified != `` {
+		if t, err := http.ParseTime(meta.LastModified); err == nil {
+			ents.RemoteModified = t
+		}
+	}
+
+	return ents, nil
+}
+
+/*
+NewFromURL is a convenience wrapper around Fetch that retrieves the
+PEN file from url using http.DefaultClient and no on-disk caching.
+Callers that need proxy support, custom timeouts, or caching across
+process restarts should call Fetch directly with a populated
+FetchOptions.
+*/
+func NewFromURL(ctx context.Context, url string) (*Enterprises, error) {
+	return Fetch(ctx, FetchOptions{URL: url})
+}
@@ -0,0 +1,130 @@
+package pen
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+const fetchFixturePEN = `
+PRIVATE ENTERPRISE NUMBERS
+
+(last updated 2024-01-02)
+
+SMI Network Management Private Enterprise Codes:
+
+PREFIX: 1.3.6.1.4.1 (1)
+
+URL: http://www.iana.org/assignments/enterprise-numbers
+
+1
+  Example One, Inc.
+    Jane Doe
+      jane&example.com
+`
+
+/*
+TestFetchNoCache verifies that an unconditional GET against a server
+with no CacheDir configured yields a correctly parsed *Enterprises.
+*/
+func TestFetchNoCache(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(fetchFixturePEN))
+	}))
+	defer srv.Close()
+
+	ents, err := Fetch(context.Background(), FetchOptions{URL: srv.URL})
+	if err != nil {
+		t.Fatalf("Fetch: %v", err)
+	}
+	if ents.Count() != 1 {
+		t.Fatalf("expected 1 Node, got %d", ents.Count())
+	}
+	if n, ok := ents.FindByOID(1); !ok || n.Organization != `Example One, Inc.` {
+		t.Fatalf("unexpected Node: %+v (ok=%t)", n, ok)
+	}
+}
+
+/*
+TestFetchConditionalGET verifies the cache lifecycle: a first Fetch
+populates CacheDir and records ETag/Last-Modified, and a second Fetch
+against the same CacheDir issues If-None-Match/If-Modified-Since --
+a 304 response short-circuits to reparsing the cached copy, stamping
+RemoteModified from the cached metadata, without the server serving
+the body again.
+*/
+func TestFetchConditionalGET(t *testing.T) {
+	const etag = `"abc123"`
+	const lastModified = `Mon, 02 Jan 2024 00:00:00 GMT`
+
+	bodyServed := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get(`If-None-Match`) == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		bodyServed++
+		w.Header().Set(`ETag`, etag)
+		w.Header().Set(`Last-Modified`, lastModified)
+		w.Write([]byte(fetchFixturePEN))
+	}))
+	defer srv.Close()
+
+	dir := t.TempDir()
+	opts := FetchOptions{URL: srv.URL, CacheDir: dir}
+
+	first, err := Fetch(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("first Fetch: %v", err)
+	}
+	if bodyServed != 1 {
+		t.Fatalf("expected 1 body fetch, got %d", bodyServed)
+	}
+	if first.RemoteModified.IsZero() {
+		t.Fatalf("expected RemoteModified to be set after first Fetch")
+	}
+
+	second, err := Fetch(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("second Fetch: %v", err)
+	}
+	if bodyServed != 1 {
+		t.Fatalf("expected 304 to short-circuit without re-serving body, body served %d times", bodyServed)
+	}
+	if second.Count() != first.Count() {
+		t.Fatalf("cached reparse produced different Node count: %d vs %d", second.Count(), first.Count())
+	}
+	if second.RemoteModified.IsZero() {
+		t.Fatalf("expected RemoteModified to be set from cached metadata")
+	}
+}
+
+/*
+TestFetchNetworkErrorFallsBackToCache verifies that once a cached copy
+exists, a subsequent Fetch whose HTTP request fails outright (rather
+than returning a response) falls back to reparsing the cache instead
+of returning an error.
+*/
+func TestFetchNetworkErrorFallsBackToCache(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(fetchFixturePEN))
+	}))
+
+	dir := t.TempDir()
+	opts := FetchOptions{URL: srv.URL, CacheDir: dir}
+
+	if _, err := Fetch(context.Background(), opts); err != nil {
+		t.Fatalf("priming Fetch: %v", err)
+	}
+
+	srv.Close() // subsequent requests to opts.URL now fail outright
+
+	ents, err := Fetch(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("expected fallback to cache, got error: %v", err)
+	}
+	if ents.Count() != 1 {
+		t.Fatalf("expected 1 Node from cached fallback, got %d", ents.Count())
+	}
+}
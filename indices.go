@@ -0,0 +1,206 @@
+package pen
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+)
+
+/*
+buildIndices populates the receiver's lookup indices from its current
+Nodes slice. It is called once by New (and by anything else that
+constructs an Enterprises from whole cloth, such as Fetch/ParseStream)
+immediately after parsing completes, so that FindBy* and the
+organization prefix search below run in O(1)/O(log n) rather than
+walking Nodes on every call. The organization fuzzy search still
+compares q against every distinct organization name.
+*/
+func (e *Enterprises) buildIndices() {
+	e.decimalIndex = make(map[int]int, len(e.Nodes))
+	e.emailIndex = make(map[string][]int)
+	e.contactIndex = make(map[string][]int)
+	e.orgIndex = make(map[string][]int)
+
+	for i := range e.Nodes {
+		n := e.Nodes[i]
+
+		e.decimalIndex[n.Decimal] = i
+
+		ck := contactKey(n.Contact)
+		e.contactIndex[ck] = append(e.contactIndex[ck], i)
+
+		ok := strings.ToLower(n.Organization)
+		e.orgIndex[ok] = append(e.orgIndex[ok], i)
+
+		for _, addr := range n.Email {
+			ek := emailKey(addr)
+			e.emailIndex[ek] = append(e.emailIndex[ek], i)
+		}
+	}
+
+	e.orgNames = make([]string, 0, len(e.orgIndex))
+	for k := range e.orgIndex {
+		e.orgNames = append(e.orgNames, k)
+	}
+	sort.Strings(e.orgNames)
+}
+
+/*
+contactKey normalizes a Contact name for indexed lookup: caseless,
+with all spaces removed, matching the comparison FindByContact has
+always performed.
+*/
+func contactKey(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, ` `, ``))
+}
+
+/*
+emailKey normalizes an email address for indexed lookup: caseless,
+with the IANA file's `&` standing in for `@` normalized away, matching
+the comparison FindByEmail has always performed.
+*/
+func emailKey(addr string) string {
+	return strings.ToLower(strings.ReplaceAll(addr, `&`, `@`))
+}
+
+/*
+FindByOrganization conducts a caseless exact match between name and
+each parsed Node's Organization field, returning the first match via
+orgIndex (or via a linear scan, if indices were never built).
+*/
+func (e Enterprises) FindByOrganization(name string) (Node, bool) {
+	key := strings.ToLower(name)
+	if e.orgIndex == nil {
+		for i := range e.Nodes {
+			if strings.ToLower(e.Nodes[i].Organization) == key {
+				return e.Nodes[i], true
+			}
+		}
+		return emptyNode, false
+	}
+
+	if idxs, ok := e.orgIndex[key]; ok && len(idxs) > 0 {
+		return e.Nodes[idxs[0]], true
+	}
+	return emptyNode, false
+}
+
+/*
+SearchByOrganizationPrefix returns every Node whose Organization
+begins with prefix, caseless. Matching walks orgNames -- a sorted
+slice of the distinct, case-folded Organization values seen during
+parsing -- starting from the first entry at or after prefix.
+*/
+func (e Enterprises) SearchByOrganizationPrefix(prefix string) (found []Node) {
+	prefix = strings.ToLower(prefix)
+
+	start := sort.SearchStrings(e.orgNames, prefix)
+	for i := start; i < len(e.orgNames) && strings.HasPrefix(e.orgNames[i], prefix); i++ {
+		for _, idx := range e.orgIndex[e.orgNames[i]] {
+			found = append(found, e.Nodes[idx])
+		}
+	}
+	return
+}
+
+/*
+SearchByOrganizationFuzzy returns every Node whose Organization is
+within maxDist Levenshtein edits of q, case-folded and with
+punctuation stripped from both sides of the comparison beforehand.
+A negative maxDist always yields no results.
+*/
+func (e Enterprises) SearchByOrganizationFuzzy(q string, maxDist int) (found []Node) {
+	if maxDist < 0 {
+		return nil
+	}
+
+	nq := normalizeForFuzzy(q)
+	for _, org := range e.orgNames {
+		if levenshteinWithin(normalizeForFuzzy(org), nq, maxDist) {
+			for _, idx := range e.orgIndex[org] {
+				found = append(found, e.Nodes[idx])
+			}
+		}
+	}
+	return
+}
+
+/*
+normalizeForFuzzy lower-cases s and strips everything but letters,
+digits and whitespace, so that punctuation differences (e.g. "Acme,
+Inc." vs "Acme Inc") don't inflate the edit distance between two
+otherwise-identical organization names.
+*/
+func normalizeForFuzzy(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) || unicode.IsSpace(r) {
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
+
+/*
+levenshteinWithin reports whether the Levenshtein distance between a
+and q is at most maxDist, using the standard dynamic-programming
+table. Rows are length len(q)+1, and computation bails out early, as
+soon as a row's minimum value exceeds maxDist, since no later row can
+ever produce a smaller final distance.
+*/
+func levenshteinWithin(a, q string, maxDist int) bool {
+	if a == q {
+		return true
+	}
+
+	ar := []rune(a)
+	qr := []rune(q)
+
+	lq := len(qr)
+	if diff := len(ar) - lq; diff > maxDist || -diff > maxDist {
+		return false
+	}
+
+	prev := make([]int, lq+1)
+	curr := make([]int, lq+1)
+	for j := 0; j <= lq; j++ {
+		prev[j] = j
+	}
+
+	for i := 1; i <= len(ar); i++ {
+		curr[0] = i
+		rowMin := curr[0]
+
+		for j := 1; j <= lq; j++ {
+			cost := 1
+			if ar[i-1] == qr[j-1] {
+				cost = 0
+			}
+
+			del := prev[j] + 1
+			ins := curr[j-1] + 1
+			sub := prev[j-1] + cost
+
+			m := del
+			if ins < m {
+				m = ins
+			}
+			if sub < m {
+				m = sub
+			}
+			curr[j] = m
+
+			if m < rowMin {
+				rowMin = m
+			}
+		}
+
+		if rowMin > maxDist {
+			return false
+		}
+
+		prev, curr = curr, prev
+	}
+
+	return prev[lq] <= maxDist
+}
@@ -0,0 +1,154 @@
+package pen
+
+import (
+	"fmt"
+	"testing"
+)
+
+/*
+orgFixture builds a small Enterprises with indices built, covering a
+handful of distinct Organization names for correctness tests of
+FindByOrganization/SearchByOrganizationPrefix/SearchByOrganizationFuzzy.
+*/
+func orgFixture() *Enterprises {
+	e := new(Enterprises)
+	e.Nodes = []Node{
+		{Decimal: 1, Organization: `Acme, Inc.`},
+		{Decimal: 2, Organization: `Acme Robotics`},
+		{Decimal: 3, Organization: `Globex Corporation`},
+		{Decimal: 4, Organization: `Société Générale`},
+	}
+	e.buildIndices()
+	return e
+}
+
+/*
+TestFindByOrganization verifies a caseless exact match via orgIndex,
+and a miss for a name with no match.
+*/
+func TestFindByOrganization(t *testing.T) {
+	e := orgFixture()
+
+	n, ok := e.FindByOrganization(`ACME, INC.`)
+	if !ok || n.Decimal != 1 {
+		t.Fatalf("expected Decimal 1, got %+v (ok=%t)", n, ok)
+	}
+
+	if _, ok := e.FindByOrganization(`Nonexistent Co`); ok {
+		t.Fatal("expected no match for nonexistent organization")
+	}
+}
+
+/*
+TestSearchByOrganizationPrefix verifies a caseless prefix search
+returns every matching Node and none that don't match.
+*/
+func TestSearchByOrganizationPrefix(t *testing.T) {
+	e := orgFixture()
+
+	found := e.SearchByOrganizationPrefix(`acme`)
+	if len(found) != 2 {
+		t.Fatalf("expected 2 matches, got %d: %+v", len(found), found)
+	}
+
+	if found := e.SearchByOrganizationPrefix(`globex`); len(found) != 1 || found[0].Decimal != 3 {
+		t.Fatalf("expected single Globex match, got %+v", found)
+	}
+
+	if found := e.SearchByOrganizationPrefix(`zzz`); len(found) != 0 {
+		t.Fatalf("expected no matches, got %+v", found)
+	}
+}
+
+/*
+TestSearchByOrganizationFuzzy verifies fuzzy search tolerates small
+edit distances, respects maxDist, and handles non-ASCII organization
+names correctly (the underlying levenshteinWithin compares runes, not
+bytes).
+*/
+func TestSearchByOrganizationFuzzy(t *testing.T) {
+	e := orgFixture()
+
+	if found := e.SearchByOrganizationFuzzy(`Acme Inc`, 3); len(found) == 0 {
+		t.Fatal("expected at least one fuzzy match for 'Acme Inc'")
+	}
+
+	if found := e.SearchByOrganizationFuzzy(`Completely Unrelated`, 2); len(found) != 0 {
+		t.Fatalf("expected no matches within tight maxDist, got %+v", found)
+	}
+
+	if found := e.SearchByOrganizationFuzzy(`anything`, -1); found != nil {
+		t.Fatalf("expected nil for negative maxDist, got %+v", found)
+	}
+
+	if found := e.SearchByOrganizationFuzzy(`Societe Generale`, 4); len(found) != 1 || found[0].Decimal != 4 {
+		t.Fatalf("expected accented org to fuzzy-match its ASCII spelling, got %+v", found)
+	}
+}
+
+/*
+TestLevenshteinWithinRunes verifies distance is computed over runes,
+not bytes, so a single accented character counts as one edit rather
+than the two-or-more bytes it occupies in UTF-8.
+*/
+func TestLevenshteinWithinRunes(t *testing.T) {
+	if !levenshteinWithin(`societe`, `société`, 2) {
+		t.Fatal("expected 'societe'/'société' to be within 2 edits")
+	}
+	if levenshteinWithin(`societe`, `société`, 1) {
+		t.Fatal("expected 'societe'/'société' to exceed 1 edit")
+	}
+}
+
+/*
+benchEnterprises builds a synthetic Enterprises of n Nodes, suitable
+for benchmarking lookups without requiring an on-disk PEN file.
+*/
+func benchEnterprises(n int) *Enterprises {
+	e := new(Enterprises)
+	e.Nodes = make([]Node, n)
+	for i := 0; i < n; i++ {
+		e.Nodes[i] = Node{
+			Decimal:      i,
+			Organization: fmt.Sprintf("Example Org %d", i),
+			Contact:      fmt.Sprintf("Contact %d", i),
+			Email:        []string{fmt.Sprintf("user%d&example.com", i)},
+		}
+	}
+	return e
+}
+
+/*
+BenchmarkFindByOIDLinear measures FindByOID against an Enterprises
+whose indices were never built, forcing the linear-scan fallback in
+oidExists.
+*/
+func BenchmarkFindByOIDLinear(b *testing.B) {
+	e := benchEnterprises(60000)
+	target := e.Count() - 1
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, ok := e.FindByOID(target); !ok {
+			b.Fatal("expected match")
+		}
+	}
+}
+
+/*
+BenchmarkFindByOIDIndexed measures FindByOID against an Enterprises
+with decimalIndex built by buildIndices, demonstrating the O(1)
+speedup over the linear-scan fallback exercised above.
+*/
+func BenchmarkFindByOIDIndexed(b *testing.B) {
+	e := benchEnterprises(60000)
+	e.buildIndices()
+	target := e.Count() - 1
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		if _, ok := e.FindByOID(target); !ok {
+			b.Fatal("expected match")
+		}
+	}
+}
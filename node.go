@@ -101,28 +101,46 @@ func (n Node) DumpNode() (entry string) {
 	return
 }
 
-func parseNode(scan *bufio.Scanner, l line) (n Node, err error) {
-	n.Decimal, err = strconv.Atoi(l.string())
+func parseNode(scan *bufio.Scanner, l Line) (n Node, err error) {
+	n.Decimal, err = strconv.Atoi(l.String())
 	if err != nil {
 		return Node{}, err
 	}
 
+	lines := make([]string, 0, 3)
 	for i := 1; i < 4; i++ {
-		if scan.Scan() {
-			next := line(scan.Text()).trimLeadingSpace()
-			switch i {
-			case 1:
-				n.Organization = next.string()
-			case 2:
-				n.Contact = next.string()
-			case 3:
-				em := strings.Split(strings.ReplaceAll(next.string(), ` `, ``), `,`)
-				n.Email = make([]string, 0, len(em))
-				for el := range em {
-					n.Email = append(n.Email, em[el])
-				}
-			}
+		if !scan.Scan() {
+			break
 		}
+		lines = append(lines, scan.Text())
 	}
+
+	fillNodeFields(&n, lines)
 	return
 }
+
+/*
+fillNodeFields populates n's Organization, Contact and Email fields
+from lines -- the (up to three) raw Organization/Contact/Email lines
+following a Node's leading decimal line, in that order. Both the
+sequential scanner-driven parseNode and the block-based parser used
+by NewWithOptions route through this shared helper so the two stay in
+lockstep.
+*/
+func fillNodeFields(n *Node, lines []string) {
+	for i, raw := range lines {
+		next := Line(raw).TrimLeadingSpace()
+		switch i {
+		case 0:
+			n.Organization = next.String()
+		case 1:
+			n.Contact = next.String()
+		case 2:
+			em := strings.Split(strings.ReplaceAll(next.String(), ` `, ``), `,`)
+			n.Email = make([]string, 0, len(em))
+			for el := range em {
+				n.Email = append(n.Email, em[el])
+			}
+		}
+	}
+}
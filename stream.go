@@ -0,0 +1,157 @@
+package pen
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+/*
+ParseStream parses r as the complete IANA Private Enterprise Numbers
+List, in the same four-line-per-entry format New expects to find on
+disk. Unlike New, which takes a path on disk, ParseStream accepts any
+io.Reader and never requires its input to be fully buffered in memory
+beforehand: it scans r line by line via
+bufio.Scanner, so callers can parse directly from an HTTP response
+body, a pipe, or any other io.Reader.
+
+If at any point parsing encounters an error, it is returned alongside
+a likely nil instance of the *Enterprises type. Else, a fully-populated
+instance of *Enterprises shall be returned alongside a nil error.
+*/
+func ParseStream(r io.Reader) (ents *Enterprises, err error) {
+	ents = new(Enterprises)
+	scan := bufio.NewScanner(r)
+
+	ct := 0
+	for scan.Scan() {
+		ct++
+		L := Line(scan.Text())
+		if L.IsZero() {
+			continue
+		}
+
+		// Lines 0 - 10 are for header info
+		if ct <= 10 {
+			if _, err := ents.setHeader(L, ct); err != nil {
+				return nil, err
+			}
+		}
+
+		// Any line that is wholly numerical indicates
+		// the start of a new entry ...
+		if L.IsNumbersOnly() {
+			if n, err := parseNode(scan, L); err == nil {
+				_ = ents.append(n) // duplicates silently ignored ...
+			} else {
+				return nil, err
+			}
+		}
+	}
+	if err := scan.Err(); err != nil {
+		return nil, err
+	}
+
+	ents.buildIndices()
+
+	return ents, nil
+}
+
+/*
+Walk invokes fn once per Node in the receiver instance, in storage
+order, stopping as soon as fn returns a non-nil error (which Walk then
+returns verbatim). It lets callers stream-process Nodes without
+holding on to -- or copying -- the full Nodes slice.
+*/
+func (e Enterprises) Walk(fn func(Node) error) error {
+	for i := range e.Nodes {
+		if err := fn(e.Nodes[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+FieldDiff describes a single changed field on a Node that exists on
+both sides of a Diff, naming the field alongside its old and new
+values.
+*/
+type FieldDiff struct {
+	Field string
+	Old,
+	New string
+}
+
+/*
+NodeDiff pairs a Node -- taken from the newer Enterprises instance
+passed to Diff -- with the FieldDiff entries distinguishing it from
+its counterpart in the older instance.
+*/
+type NodeDiff struct {
+	Node    Node
+	Changed []FieldDiff
+}
+
+/*
+Changes is the result of comparing two Enterprises instances via
+Diff: Nodes present in the newer instance but absent from the older
+one (Added), Nodes present in the older instance but absent from the
+newer one (Removed), and Nodes present in both but differing in one
+or more of Organization, Contact or Email (Modified).
+*/
+type Changes struct {
+	Added    []Node
+	Removed  []Node
+	Modified []NodeDiff
+}
+
+/*
+Diff compares the receiver instance (the older pull) against other
+(the newer pull), matching Nodes by Decimal, and returns the
+resulting Changes. Email is compared via its comma-joined string
+form, so a reordering of otherwise-identical addresses is reported as
+a modification.
+
+Diff exists for administrators running scheduled refreshes who want
+to emit a change report, or feed downstream systems only the delta
+since the previous pull, rather than the complete Nodes slice every
+time.
+*/
+func (e Enterprises) Diff(other *Enterprises) (c Changes) {
+	seen := make(map[int]bool, other.Count())
+
+	for i := range other.Nodes {
+		on := other.Nodes[i]
+		seen[on.Decimal] = true
+
+		n, ok := e.FindByOID(on.Decimal)
+		if !ok {
+			c.Added = append(c.Added, on)
+			continue
+		}
+
+		var changed []FieldDiff
+		if n.Organization != on.Organization {
+			changed = append(changed, FieldDiff{Field: `Organization`, Old: n.Organization, New: on.Organization})
+		}
+		if n.Contact != on.Contact {
+			changed = append(changed, FieldDiff{Field: `Contact`, Old: n.Contact, New: on.Contact})
+		}
+		if oldEmail, newEmail := strings.Join(n.Email, `,`), strings.Join(on.Email, `,`); oldEmail != newEmail {
+			changed = append(changed, FieldDiff{Field: `Email`, Old: oldEmail, New: newEmail})
+		}
+
+		if len(changed) > 0 {
+			c.Modified = append(c.Modified, NodeDiff{Node: on, Changed: changed})
+		}
+	}
+
+	for i := range e.Nodes {
+		if !seen[e.Nodes[i].Decimal] {
+			c.Removed = append(c.Removed, e.Nodes[i])
+		}
+	}
+
+	return
+}
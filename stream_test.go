@@ -0,0 +1,126 @@
+package pen
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+const streamFixturePEN = `
+PRIVATE ENTERPRISE NUMBERS
+
+(last updated 2024-01-02)
+
+SMI Network Management Private Enterprise Codes:
+
+PREFIX: 1.3.6.1.4.1 (1)
+
+URL: http://www.iana.org/assignments/enterprise-numbers
+
+1
+  Example One, Inc.
+    Jane Doe
+      jane&example.com
+2
+  Example Two, LLC
+    John Roe
+      john&example.com
+`
+
+/*
+TestWalk verifies Walk visits every Node in storage order, and stops
+as soon as fn returns a non-nil error, returning that error verbatim.
+*/
+func TestWalk(t *testing.T) {
+	ents, err := ParseStream(strings.NewReader(streamFixturePEN))
+	if err != nil {
+		t.Fatalf("ParseStream: %v", err)
+	}
+
+	var seen []int
+	if err := ents.Walk(func(n Node) error {
+		seen = append(seen, n.Decimal)
+		return nil
+	}); err != nil {
+		t.Fatalf("Walk: %v", err)
+	}
+	if len(seen) != 2 || seen[0] != 1 || seen[1] != 2 {
+		t.Fatalf("expected [1 2] in order, got %v", seen)
+	}
+
+	errStop := errors.New("stop")
+	calls := 0
+	err = ents.Walk(func(n Node) error {
+		calls++
+		return errStop
+	})
+	if err != errStop {
+		t.Fatalf("expected errStop, got %v", err)
+	}
+	if calls != 1 {
+		t.Fatalf("expected Walk to stop after first error, got %d calls", calls)
+	}
+}
+
+/*
+TestDiff verifies Added/Removed/Modified detection, matching Nodes by
+Decimal and reporting a FieldDiff for each of Organization, Contact and
+Email that changed between the two sides.
+*/
+func TestDiff(t *testing.T) {
+	older := &Enterprises{Nodes: []Node{
+		{Decimal: 1, Organization: `Acme, Inc.`, Contact: `Jane Doe`, Email: []string{`jane&example.com`}},
+		{Decimal: 2, Organization: `Stale Corp`, Contact: `John Roe`, Email: []string{`john&example.com`}},
+	}}
+	newer := &Enterprises{Nodes: []Node{
+		{Decimal: 1, Organization: `Acme Robotics`, Contact: `Jane A. Doe`, Email: []string{`jane&example.com`, `jane.doe&example.com`}},
+		{Decimal: 3, Organization: `Globex Corporation`, Contact: `Hank Scorpio`, Email: []string{`hank&globex.com`}},
+	}}
+
+	c := older.Diff(newer)
+
+	if len(c.Added) != 1 || c.Added[0].Decimal != 3 {
+		t.Fatalf("expected Decimal 3 added, got %+v", c.Added)
+	}
+	if len(c.Removed) != 1 || c.Removed[0].Decimal != 2 {
+		t.Fatalf("expected Decimal 2 removed, got %+v", c.Removed)
+	}
+	if len(c.Modified) != 1 || c.Modified[0].Node.Decimal != 1 {
+		t.Fatalf("expected Decimal 1 modified, got %+v", c.Modified)
+	}
+
+	changed := c.Modified[0].Changed
+	if len(changed) != 3 {
+		t.Fatalf("expected Organization, Contact and Email to all be flagged as changed, got %+v", changed)
+	}
+
+	byField := make(map[string]FieldDiff, len(changed))
+	for _, fd := range changed {
+		byField[fd.Field] = fd
+	}
+
+	if fd, ok := byField[`Organization`]; !ok || fd.Old != `Acme, Inc.` || fd.New != `Acme Robotics` {
+		t.Fatalf("unexpected Organization FieldDiff: %+v (ok=%t)", fd, ok)
+	}
+	if fd, ok := byField[`Contact`]; !ok || fd.Old != `Jane Doe` || fd.New != `Jane A. Doe` {
+		t.Fatalf("unexpected Contact FieldDiff: %+v (ok=%t)", fd, ok)
+	}
+	if fd, ok := byField[`Email`]; !ok || fd.Old != `jane&example.com` || fd.New != `jane&example.com,jane.doe&example.com` {
+		t.Fatalf("unexpected Email FieldDiff: %+v (ok=%t)", fd, ok)
+	}
+}
+
+/*
+TestDiffNoChanges verifies that a Node present on both sides with
+identical fields produces no Modified entry.
+*/
+func TestDiffNoChanges(t *testing.T) {
+	e := &Enterprises{Nodes: []Node{
+		{Decimal: 1, Organization: `Acme, Inc.`, Contact: `Jane Doe`, Email: []string{`jane&example.com`}},
+	}}
+
+	c := e.Diff(&Enterprises{Nodes: e.Nodes})
+	if len(c.Added) != 0 || len(c.Removed) != 0 || len(c.Modified) != 0 {
+		t.Fatalf("expected no Changes for identical Enterprises, got %+v", c)
+	}
+}